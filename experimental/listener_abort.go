@@ -0,0 +1,31 @@
+package experimental
+
+import (
+	"context"
+
+	"github.com/tetratelabs/wazero/api"
+)
+
+// FunctionListenerAbortCapable is implemented by a FunctionListener that also wants to observe a call
+// that unwinds via a trap or panic instead of returning normally. Before and After are otherwise
+// guaranteed to be paired, but a call that traps (e.g. integer divide by zero, an out-of-bounds memory
+// access, or a host function panic) previously left Before calls with no matching After. Implement this
+// interface to be notified of that case too: the engine detects it with a type assertion, so existing
+// FunctionListener implementations that don't implement it keep compiling unchanged.
+type FunctionListenerAbortCapable interface {
+	FunctionListener
+
+	// Abort is invoked instead of After when the call begun by the most recent Before call unwound via a
+	// trap or a panic recovered from a host function, with err describing the cause. Implementations that
+	// maintain a call stack (e.g. for tracing) should pop it here exactly as they would have in After.
+	Abort(ctx context.Context, mod api.Module, def api.FunctionDefinition, err error)
+}
+
+// NotifyFunctionListenerAbort invokes l's Abort hook when it implements FunctionListenerAbortCapable, and
+// is a no-op otherwise. Call it from a deferred, recovered panic handler so that every Before is paired
+// with either an After or an Abort.
+func NotifyFunctionListenerAbort(ctx context.Context, l FunctionListener, mod api.Module, def api.FunctionDefinition, err error) {
+	if aborter, ok := l.(FunctionListenerAbortCapable); ok {
+		aborter.Abort(ctx, mod, def, err)
+	}
+}
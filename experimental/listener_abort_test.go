@@ -0,0 +1,38 @@
+package experimental
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/internal/testing/require"
+)
+
+type noopListener struct{ FunctionListener }
+
+type abortCapableListener struct {
+	noopListener
+	aborted error
+}
+
+func (l *abortCapableListener) Abort(_ context.Context, _ api.Module, _ api.FunctionDefinition, err error) {
+	l.aborted = err
+}
+
+func TestNotifyFunctionListenerAbort(t *testing.T) {
+	t.Run("invokes Abort when implemented", func(t *testing.T) {
+		l := &abortCapableListener{}
+		cause := errors.New("integer divide by zero")
+		NotifyFunctionListenerAbort(context.Background(), l, nil, nil, cause)
+		require.Equal(t, cause, l.aborted)
+	})
+
+	t.Run("no-ops when not implemented", func(t *testing.T) {
+		l := &noopListener{}
+		captured := require.CapturePanic(func() {
+			NotifyFunctionListenerAbort(context.Background(), l, nil, nil, errors.New("boom"))
+		})
+		require.Nil(t, captured)
+	})
+}
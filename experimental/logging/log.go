@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	internallogging "github.com/tetratelabs/wazero/internal/logging"
+	wasilogging "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1/logging"
+)
+
+// NewLoggingListenerFactory returns a human-readable experimental.FunctionListenerFactory that writes one
+// indented "--> module.name(params)" / "<-- module.name(results)" line pair per host function call to w, tailable in a
+// terminal. See NewStructuredLoggingListenerFactory for the machine-readable equivalent.
+//
+// By default every call is logged; pass WithLogScopes to narrow that to particular
+// internallogging.LogScopes, and WithLogIncludeFunctions/WithLogExcludeFunctions to further narrow (or
+// widen) logging by function name regardless of scope.
+func NewLoggingListenerFactory(w io.Writer, options ...LoggingOption) experimental.FunctionListenerFactory {
+	c := &loggingConfig{scopes: internallogging.LogScopeAll}
+	for _, o := range options {
+		o(c)
+	}
+	return &loggingListenerFactory{w: w, config: c}
+}
+
+type loggingListenerFactory struct {
+	w      io.Writer
+	config *loggingConfig
+	indent int
+}
+
+// NewListener implements the same method as documented on experimental.FunctionListenerFactory.
+func (f *loggingListenerFactory) NewListener(def api.FunctionDefinition) experimental.FunctionListener {
+	if !wasilogging.Admit(def, f.config.scopes, f.config.include, f.config.exclude) {
+		return nil
+	}
+	return &loggingListener{w: f.w, def: def, factory: f}
+}
+
+type loggingListener struct {
+	w       io.Writer
+	def     api.FunctionDefinition
+	factory *loggingListenerFactory
+	params  []uint64
+}
+
+// Before implements the same method as documented on experimental.FunctionListener.
+func (l *loggingListener) Before(_ context.Context, mod api.Module, _ api.FunctionDefinition, params []uint64, _ experimental.StackIterator) {
+	l.params = params
+	l.writeLine("-->", mod, paramsString(params))
+	l.factory.indent++
+}
+
+// After implements the same method as documented on experimental.FunctionListener.
+func (l *loggingListener) After(_ context.Context, mod api.Module, _ api.FunctionDefinition, results []uint64) {
+	l.factory.indent--
+	l.writeLine("<--", mod, paramsString(results))
+}
+
+// Abort implements the same method as documented on experimental.FunctionListenerAbortCapable.
+func (l *loggingListener) Abort(_ context.Context, mod api.Module, _ api.FunctionDefinition, err error) {
+	l.factory.indent--
+	l.writeLine("<--", mod, fmt.Sprintf("!trap: %s", err))
+}
+
+func (l *loggingListener) writeLine(arrow string, mod api.Module, suffix string) {
+	// Indent one tab per level of call nesting, so a host function called from within a wasm function
+	// called from within another wasm function reads like a call stack.
+	indent := strings.Repeat("\t", l.factory.indent)
+	fmt.Fprintf(l.w, "%s%s %s.%s%s\n", indent, arrow, mod.Name(), l.def.Name(), suffix)
+}
+
+// paramsString renders raw parameter/result values the same way for every call, since the listener
+// doesn't have enough type information (only api.FunctionDefinition names) to safely choose between a
+// signed or unsigned decimal rendering per value.
+func paramsString(values []uint64) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = fmt.Sprintf("%d", v)
+	}
+	return "(" + strings.Join(strs, ",") + ")"
+}
@@ -0,0 +1,149 @@
+// Package observer provides an in-memory experimental.FunctionListenerFactory for asserting on host
+// function call traces in tests, modeled on the observer core used by zap's zaptest/observer package.
+// It exists because testing host-call behavior otherwise means wiring experimental/logging to a
+// bytes.Buffer and string-matching pretty-printed output, which is brittle and hard to read in a diff.
+package observer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	"github.com/tetratelabs/wazero/internal/logging"
+	wasilogging "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1/logging"
+)
+
+// LoggedCall is a single observed host function call.
+type LoggedCall struct {
+	// Function is the name of the host function, e.g. "fd_read".
+	Function string
+	// Scope is the logging.LogScopes this call was classified into.
+	Scope logging.LogScopes
+	// Params are the raw parameter values passed to the function.
+	Params []uint64
+	// Results are the raw result values returned by the function. This is nil when Err is not nil.
+	Results []uint64
+	// Err is non-nil when the call aborted (e.g. trapped) instead of returning normally.
+	Err error
+}
+
+// New returns a Factory that can be passed to wazero.ModuleConfig.WithListener (via the
+// experimental.WithFunctionListenerFactory context key) and an *ObservedLogs that accumulates every
+// call the Factory's listeners observe.
+func New() (Factory, *ObservedLogs) {
+	logs := &ObservedLogs{}
+	return Factory{logs: logs}, logs
+}
+
+// Factory implements experimental.FunctionListenerFactory, recording every call into the ObservedLogs
+// returned alongside it by New.
+type Factory struct {
+	logs *ObservedLogs
+}
+
+// NewListener implements the same method as documented on experimental.FunctionListenerFactory.
+func (f Factory) NewListener(def api.FunctionDefinition) experimental.FunctionListener {
+	return &listener{def: def, logs: f.logs}
+}
+
+type listener struct {
+	def    api.FunctionDefinition
+	logs   *ObservedLogs
+	params []uint64
+}
+
+// Before implements the same method as documented on experimental.FunctionListener.
+func (l *listener) Before(_ context.Context, _ api.Module, _ api.FunctionDefinition, params []uint64, _ experimental.StackIterator) {
+	l.params = params
+}
+
+// After implements the same method as documented on experimental.FunctionListener.
+func (l *listener) After(_ context.Context, _ api.Module, _ api.FunctionDefinition, results []uint64) {
+	l.logs.append(LoggedCall{Function: l.def.Name(), Scope: scopeOf(l.def), Params: l.params, Results: results})
+}
+
+// Abort implements the same method as documented on experimental.FunctionListenerAbortCapable.
+func (l *listener) Abort(_ context.Context, _ api.Module, _ api.FunctionDefinition, err error) {
+	l.logs.append(LoggedCall{Function: l.def.Name(), Scope: scopeOf(l.def), Params: l.params, Err: err})
+}
+
+// scopeOf returns the first logging.LogScopes flag that wasilogging.IsInLogScope admits def into, reusing
+// that predicate so ObservedLogs.FilterScope agrees with every other scope-aware consumer in the repo.
+func scopeOf(def api.FunctionDefinition) logging.LogScopes {
+	for _, scope := range []logging.LogScopes{
+		logging.LogScopeArgs, logging.LogScopeClock, logging.LogScopeFilesystem,
+		logging.LogScopePoll, logging.LogScopeProc, logging.LogScopeRandom, logging.LogScopeSock,
+	} {
+		if wasilogging.IsInLogScope(def, scope) {
+			return scope
+		}
+	}
+	return logging.LogScopeNone
+}
+
+// ObservedLogs is a concurrency-safe collection of LoggedCall accumulated by a Factory.
+type ObservedLogs struct {
+	mu    sync.Mutex
+	calls []LoggedCall
+}
+
+func (o *ObservedLogs) append(c LoggedCall) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.calls = append(o.calls, c)
+}
+
+// Len returns the number of calls observed so far.
+func (o *ObservedLogs) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.calls)
+}
+
+// All returns every call observed so far, in call order. The returned slice is a copy and safe to
+// retain across further calls into the observed module.
+func (o *ObservedLogs) All() []LoggedCall {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ret := make([]LoggedCall, len(o.calls))
+	copy(ret, o.calls)
+	return ret
+}
+
+// TakeAll returns every call observed so far, in call order, and clears the log. This is useful for
+// asserting on calls made by one guest invocation without calls from a prior invocation leaking in.
+func (o *ObservedLogs) TakeAll() []LoggedCall {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ret := o.calls
+	o.calls = nil
+	return ret
+}
+
+// FilterFunction returns a new ObservedLogs containing only the calls to the named function, e.g. "fd_read".
+func (o *ObservedLogs) FilterFunction(name string) *ObservedLogs {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ret := &ObservedLogs{}
+	for _, c := range o.calls {
+		if c.Function == name {
+			ret.calls = append(ret.calls, c)
+		}
+	}
+	return ret
+}
+
+// FilterScope returns a new ObservedLogs containing only the calls admitted by scopes, using the same
+// logging.LogScopes predicate as experimental/logging and internal/wasi_snapshot_preview1/logging.
+func (o *ObservedLogs) FilterScope(scopes logging.LogScopes) *ObservedLogs {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ret := &ObservedLogs{}
+	for _, c := range o.calls {
+		if c.Scope&scopes != 0 {
+			ret.calls = append(ret.calls, c)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,64 @@
+package observer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tetratelabs/wazero/internal/logging"
+	"github.com/tetratelabs/wazero/internal/testing/require"
+	"github.com/tetratelabs/wazero/internal/testing/testfn"
+	. "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1"
+)
+
+func TestObservedLogs(t *testing.T) {
+	factory, logs := New()
+
+	fdRead := testfn.New(FdReadName)
+	randomGet := testfn.New(RandomGetName)
+	procExit := testfn.New(ProcExitName)
+
+	l := factory.NewListener(fdRead)
+	l.Before(context.Background(), nil, fdRead, []uint64{3, 4, 5}, nil)
+	l.After(context.Background(), nil, fdRead, []uint64{0})
+
+	l = factory.NewListener(randomGet)
+	l.Before(context.Background(), nil, randomGet, []uint64{6, 7}, nil)
+	l.After(context.Background(), nil, randomGet, []uint64{0})
+
+	l = factory.NewListener(procExit)
+	l.Before(context.Background(), nil, procExit, []uint64{1}, nil)
+	l.Abort(context.Background(), nil, procExit, errors.New("exit status 1"))
+
+	require.Equal(t, 3, logs.Len())
+
+	all := logs.All()
+	require.Equal(t, 3, len(all))
+	require.Equal(t, FdReadName, all[0].Function)
+	require.Equal(t, []uint64{3, 4, 5}, all[0].Params)
+	require.Equal(t, []uint64{0}, all[0].Results)
+	require.Nil(t, all[0].Err)
+	require.Equal(t, ProcExitName, all[2].Function)
+	require.EqualError(t, all[2].Err, "exit status 1")
+
+	t.Run("FilterFunction", func(t *testing.T) {
+		filtered := logs.FilterFunction(RandomGetName)
+		require.Equal(t, 1, filtered.Len())
+		require.Equal(t, RandomGetName, filtered.All()[0].Function)
+	})
+
+	t.Run("FilterScope", func(t *testing.T) {
+		filtered := logs.FilterScope(logging.LogScopeFilesystem)
+		require.Equal(t, 1, filtered.Len())
+		require.Equal(t, FdReadName, filtered.All()[0].Function)
+
+		filtered = logs.FilterScope(logging.LogScopeFilesystem | logging.LogScopeRandom)
+		require.Equal(t, 2, filtered.Len())
+	})
+
+	t.Run("TakeAll clears the log", func(t *testing.T) {
+		taken := logs.TakeAll()
+		require.Equal(t, 3, len(taken))
+		require.Equal(t, 0, logs.Len())
+	})
+}
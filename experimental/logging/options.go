@@ -0,0 +1,37 @@
+package logging
+
+import internallogging "github.com/tetratelabs/wazero/internal/logging"
+
+// LoggingOption further tunes which host function calls a listener factory created by this package
+// observes, on top of the coarse-grained internallogging.LogScopes passed to (or, for
+// NewLoggingListenerFactory, defaulted or set via WithLogScopes on) the factory constructor.
+type LoggingOption func(*loggingConfig)
+
+type loggingConfig struct {
+	scopes  internallogging.LogScopes
+	include []string
+	exclude []string
+}
+
+// WithLogScopes narrows NewLoggingListenerFactory's logging to only the given internallogging.LogScopes,
+// in place of its default of internallogging.LogScopeAll. It has no effect on
+// NewStructuredLoggingListenerFactory, which already takes its scopes as a constructor parameter.
+func WithLogScopes(scopes internallogging.LogScopes) LoggingOption {
+	return func(c *loggingConfig) { c.scopes = scopes }
+}
+
+// WithLogIncludeFunctions narrows logging to only WASI functions matching one of the given path.Match
+// glob patterns (e.g. "fd_*", "path_open"), regardless of which internallogging.LogScopes were
+// requested. An empty/unset include list falls back to scopes, so this is opt-in. exclude still wins
+// over include: see WithLogExcludeFunctions.
+func WithLogIncludeFunctions(patterns ...string) LoggingOption {
+	return func(c *loggingConfig) { c.include = patterns }
+}
+
+// WithLogExcludeFunctions suppresses logging of WASI functions matching one of the given path.Match glob
+// patterns (e.g. "fd_read", "fd_*"), even if they are otherwise admitted by scopes or
+// WithLogIncludeFunctions. This is the knob to reach for to silence a few noisy functions (fd_read,
+// fd_write) without losing the rest of their logging.LogScopes.
+func WithLogExcludeFunctions(patterns ...string) LoggingOption {
+	return func(c *loggingConfig) { c.exclude = patterns }
+}
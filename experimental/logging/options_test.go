@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tetratelabs/wazero/internal/logging"
+	"github.com/tetratelabs/wazero/internal/testing/require"
+	"github.com/tetratelabs/wazero/internal/testing/testfn"
+	. "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1"
+)
+
+func TestStructuredListenerFactory_WithLogIncludeExcludeFunctions(t *testing.T) {
+	fdRead := testfn.New(FdReadName)
+	clockTimeGet := testfn.New(ClockTimeGetName)
+
+	tests := []struct {
+		name     string
+		options  []LoggingOption
+		fnd      *testfn.FunctionDefinition
+		expected bool
+	}{
+		{
+			name:     "admitted by scope, no options",
+			fnd:      fdRead,
+			expected: true,
+		},
+		{
+			name:     "admitted by scope but excluded",
+			options:  []LoggingOption{WithLogExcludeFunctions("fd_*")},
+			fnd:      fdRead,
+			expected: false,
+		},
+		{
+			name:     "rejected by scope, forced in by include",
+			options:  []LoggingOption{WithLogIncludeFunctions("clock_time_get")},
+			fnd:      clockTimeGet,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			factory := NewStructuredLoggingListenerFactory(&bytes.Buffer{}, logging.LogScopeFilesystem, tc.options...)
+			listener := factory.NewListener(tc.fnd)
+			require.Equal(t, tc.expected, listener != nil)
+		})
+	}
+}
+
+// TestLoggingListenerFactory_WithLogIncludeExcludeFunctions mirrors
+// TestStructuredListenerFactory_WithLogIncludeExcludeFunctions, asserting the human-readable factory
+// admits/excludes calls the same way the structured one does, via the same WithLogScopes/
+// WithLogIncludeFunctions/WithLogExcludeFunctions options.
+func TestLoggingListenerFactory_WithLogIncludeExcludeFunctions(t *testing.T) {
+	fdRead := testfn.New(FdReadName)
+	clockTimeGet := testfn.New(ClockTimeGetName)
+
+	tests := []struct {
+		name     string
+		options  []LoggingOption
+		fnd      *testfn.FunctionDefinition
+		expected bool
+	}{
+		{
+			name:     "admitted by scope, no include/exclude options",
+			options:  []LoggingOption{WithLogScopes(logging.LogScopeFilesystem)},
+			fnd:      fdRead,
+			expected: true,
+		},
+		{
+			name:     "admitted by scope but excluded",
+			options:  []LoggingOption{WithLogScopes(logging.LogScopeFilesystem), WithLogExcludeFunctions("fd_*")},
+			fnd:      fdRead,
+			expected: false,
+		},
+		{
+			name:     "rejected by scope, forced in by include",
+			options:  []LoggingOption{WithLogScopes(logging.LogScopeFilesystem), WithLogIncludeFunctions("clock_time_get")},
+			fnd:      clockTimeGet,
+			expected: true,
+		},
+		{
+			name:     "default scopes admit everything without options",
+			fnd:      clockTimeGet,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			factory := NewLoggingListenerFactory(&bytes.Buffer{}, tc.options...)
+			listener := factory.NewListener(tc.fnd)
+			require.Equal(t, tc.expected, listener != nil)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	internallogging "github.com/tetratelabs/wazero/internal/logging"
+	wasilogging "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1/logging"
+)
+
+// NewStructuredLoggingListenerFactory is like NewLoggingListenerFactory, except it writes one JSON object per
+// host function call instead of a human-readable line. This is the format to reach for when host call traces
+// are shipped to a log aggregator (e.g. Loki) instead of read by a human tailing a terminal.
+func NewStructuredLoggingListenerFactory(w io.Writer, scopes internallogging.LogScopes, options ...LoggingOption) experimental.FunctionListenerFactory {
+	c := &loggingConfig{}
+	for _, o := range options {
+		o(c)
+	}
+	return &structuredListenerFactory{w: w, scopes: scopes, config: c}
+}
+
+type structuredListenerFactory struct {
+	w      io.Writer
+	scopes internallogging.LogScopes
+	config *loggingConfig
+}
+
+// NewListener implements the same method as documented on experimental.FunctionListenerFactory.
+func (f *structuredListenerFactory) NewListener(def api.FunctionDefinition) experimental.FunctionListener {
+	if !wasilogging.Admit(def, f.scopes, f.config.include, f.config.exclude) {
+		return nil
+	}
+	return &structuredListener{w: f.w, def: def, scope: scopeName(def, f.scopes)}
+}
+
+type structuredListener struct {
+	w      io.Writer
+	def    api.FunctionDefinition
+	scope  string
+	begin  time.Time
+	params []uint64
+}
+
+// structuredLogEntry is the shape of each line written by a structuredListener. Fields are exported so
+// encoding/json can marshal them, and each struct tag gives the field's JSON name.
+type structuredLogEntry struct {
+	Time       string         `json:"ts"`
+	Module     string         `json:"module"`
+	Function   string         `json:"function"`
+	Scope      string         `json:"scope"`
+	Params     map[string]any `json:"params,omitempty"`
+	Results    map[string]any `json:"results,omitempty"`
+	Errno      string         `json:"errno,omitempty"`
+	DurationNs int64          `json:"duration_ns"`
+}
+
+// Before implements the same method as documented on experimental.FunctionListener.
+func (l *structuredListener) Before(_ context.Context, _ api.Module, _ api.FunctionDefinition, params []uint64, _ experimental.StackIterator) {
+	l.begin = time.Now()
+	l.params = params
+}
+
+// After implements the same method as documented on experimental.FunctionListener.
+func (l *structuredListener) After(_ context.Context, mod api.Module, _ api.FunctionDefinition, results []uint64) {
+	l.log(mod, namedValues(l.def.ResultNames(), results), errnoOf(l.def, results), "")
+}
+
+// Abort implements the same method as documented on experimental.FunctionListenerAbortCapable.
+func (l *structuredListener) Abort(_ context.Context, mod api.Module, _ api.FunctionDefinition, err error) {
+	l.log(mod, nil, "", err.Error())
+}
+
+func (l *structuredListener) log(mod api.Module, results map[string]any, errno, abortErr string) {
+	entry := structuredLogEntry{
+		Time:       l.begin.UTC().Format(time.RFC3339Nano),
+		Module:     mod.Name(),
+		Function:   l.def.Name(),
+		Scope:      l.scope,
+		Params:     namedValues(l.def.ParamNames(), l.params),
+		Results:    results,
+		Errno:      errno,
+		DurationNs: time.Since(l.begin).Nanoseconds(),
+	}
+	if abortErr != "" {
+		entry.Errno = abortErr
+	}
+	// An encoding error here isn't actionable by the guest or the host function being observed, so it is
+	// intentionally ignored, consistent with the human-readable listener's best-effort writes.
+	_ = json.NewEncoder(l.w).Encode(entry)
+}
+
+// namedValues zips names (from api.FunctionDefinition.ParamNames/ResultNames) with raw uint64 values
+// recorded from the experimental.FunctionListener hooks, falling back to positional names when the definition
+// doesn't describe one.
+func namedValues(names []string, values []uint64) map[string]any {
+	if len(values) == 0 {
+		return nil
+	}
+	ret := make(map[string]any, len(values))
+	for i, v := range values {
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		ret[name] = v
+	}
+	return ret
+}
+
+// errnoOf returns the WASI errno result as a decimal string when the function definition's last result
+// is conventionally named "errno", or "" when there is nothing to report.
+func errnoOf(def api.FunctionDefinition, results []uint64) string {
+	names := def.ResultNames()
+	if len(names) == 0 || len(results) == 0 {
+		return ""
+	}
+	if names[len(names)-1] != "errno" {
+		return ""
+	}
+	return fmt.Sprintf("%d", results[len(results)-1])
+}
+
+// scopeName returns the string label of whichever internallogging.LogScopes flag admitted def, reusing
+// wasilogging.IsInLogScope so the classification rules live in exactly one place.
+func scopeName(def api.FunctionDefinition, scopes internallogging.LogScopes) string {
+	for _, s := range []struct {
+		flag internallogging.LogScopes
+		name string
+	}{
+		{internallogging.LogScopeArgs, "args"},
+		{internallogging.LogScopeClock, "clock"},
+		{internallogging.LogScopeFilesystem, "filesystem"},
+		{internallogging.LogScopePoll, "poll"},
+		{internallogging.LogScopeProc, "proc"},
+		{internallogging.LogScopeRandom, "random"},
+		{internallogging.LogScopeSock, "sock"},
+	} {
+		if scopes&s.flag != 0 && wasilogging.IsInLogScope(def, s.flag) {
+			return s.name
+		}
+	}
+	return "unknown"
+}
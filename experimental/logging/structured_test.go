@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/tetratelabs/wazero/api"
+	internallogging "github.com/tetratelabs/wazero/internal/logging"
+	"github.com/tetratelabs/wazero/internal/testing/require"
+	"github.com/tetratelabs/wazero/internal/testing/testfn"
+	. "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1"
+)
+
+// TestScopeName asserts that the "scope" tag a structuredListener would emit always names the
+// internallogging.LogScopes flag that actually admitted the call, i.e. it stays in sync with
+// wasilogging.IsInLogScope rather than drifting into its own classification.
+func TestScopeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fnd      api.FunctionDefinition
+		scopes   internallogging.LogScopes
+		expected string
+	}{
+		{
+			name:     "clockTimeGet in LogScopeClock",
+			fnd:      testfn.New(ClockTimeGetName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "clock",
+		},
+		{
+			name:     "fdRead in LogScopeFilesystem",
+			fnd:      testfn.New(FdReadName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "filesystem",
+		},
+		{
+			name:     "randomGet in LogScopeRandom",
+			fnd:      testfn.New(RandomGetName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "random",
+		},
+		{
+			name:     "sockAccept in LogScopeSock",
+			fnd:      testfn.New(SockAcceptName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "sock",
+		},
+		{
+			name:     "pollOneoff in LogScopePoll",
+			fnd:      testfn.New(PollOneoffName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "poll",
+		},
+		{
+			name:     "procExit in LogScopeProc",
+			fnd:      testfn.New(ProcExitName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "proc",
+		},
+		{
+			name:     "argsGet in LogScopeArgs",
+			fnd:      testfn.New(ArgsGetName),
+			scopes:   internallogging.LogScopeAll,
+			expected: "args",
+		},
+		{
+			name:     "pollOneoff excluded when scopes omit LogScopePoll",
+			fnd:      testfn.New(PollOneoffName),
+			scopes:   internallogging.LogScopeAll &^ internallogging.LogScopePoll,
+			expected: "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, scopeName(tc.fnd, tc.scopes))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package interpreter
+
+import "fmt"
+
+// blockSignature is the resolved (paramCount, resultCount) arity of a structured control instruction's
+// (block/loop/if) block type. The multi-value proposal generalizes the MVP binary format's block type,
+// which could only encode zero or one result and never any parameters, to also allow a type index
+// referencing a FunctionType with any number of parameters and results.
+type blockSignature struct {
+	// paramCount is how many values the block consumes off the stack on entry, which are also the values
+	// a loop's back-edge br target expects.
+	paramCount int
+	// resultCount is how many values the block leaves on the stack on a normal fallthrough exit, which is
+	// also the arity every br/br_if/br_table targeting this block (other than a loop's own back-edge)
+	// must carry.
+	resultCount int
+}
+
+// resolveBlockSignature computes a blockSignature for a block/loop/if's encoded type. blockType is the
+// signed LEB128-decoded value wazeroir's lowering already extracts from the binary: -0x40 for the empty
+// type, one of the negative single-valtype encodings (exactly one result), or a non-negative index into
+// the module's type section for the multi-value form. lookupFunctionType resolves that index to its
+// parameter/result counts, mirroring how compiler.go already resolves call_indirect's type index.
+func resolveBlockSignature(blockType int64, lookupFunctionType func(typeIndex uint32) (paramCount, resultCount int, err error)) (blockSignature, error) {
+	switch {
+	case blockType == -0x40:
+		return blockSignature{}, nil
+	case blockType < 0:
+		// A negative single-valtype encoding (i32, i64, f32, f64, v128, funcref, externref): exactly one
+		// result, no parameters, same as before multi-value existed.
+		return blockSignature{resultCount: 1}, nil
+	default:
+		paramCount, resultCount, err := lookupFunctionType(uint32(blockType))
+		if err != nil {
+			return blockSignature{}, fmt.Errorf("resolving block type %d: %w", blockType, err)
+		}
+		return blockSignature{paramCount: paramCount, resultCount: resultCount}, nil
+	}
+}
+
+// branchArity is the number of values a br/br_if/br_table instruction targeting a structured control
+// instruction must carry across the branch: a loop's own back-edge (branching to its start) carries its
+// paramCount, while every other branch target (a block/if's end, or a loop branched to from outside)
+// carries its resultCount. This mirrors the "break arity" wazeroir already has to record per label so
+// callNativeFunc knows how many stack values to copy across a branch, generalized here from the old
+// at-most-one-value assumption to the arity a multi-value block type declares.
+func (s blockSignature) branchArity(isLoopBackEdge bool) int {
+	if isLoopBackEdge {
+		return s.paramCount
+	}
+	return s.resultCount
+}
+
+// validateStructuredControlArity reports an error if values, the number of operand stack values actually
+// available to a structured control instruction on entry, doesn't match the block type's declared
+// paramCount. It is the per-block/loop/if counterpart of the parameter/result count checks that
+// signature.go's validation pass (not present in this snapshot) does for plain function calls; no code in
+// this snapshot calls it.
+func validateStructuredControlArity(instruction string, sig blockSignature, values int) error {
+	if values != sig.paramCount {
+		return fmt.Errorf("%s: expected %d parameter(s) on the stack, but have %d", instruction, sig.paramCount, values)
+	}
+	return nil
+}
+
+// errBranchArityUnderflow is the trap callEngine.branch raises when the operand stack holds fewer values
+// than the branch's declared arity. This can only happen if invalid bytecode reached callNativeFunc, i.e.
+// signature.go's validation pass (not present in this snapshot) has a bug: a correctly validated module
+// can never hit this, so callEngine.branch traps instead of silently carrying fewer values across.
+var errBranchArityUnderflow = fmt.Errorf("not enough values on the stack to carry across the branch")
+
+// branch pops sig.branchArity(isLoopBackEdge) values off the top of ce.stack (the values a br/br_if/
+// br_table targeting sig's structured control instruction carries across the branch) and returns them,
+// leaving the rest of the operand stack in place for the branch target to continue from. It traps with
+// errBranchArityUnderflow instead of silently truncating when the stack holds fewer values than the
+// arity calls for, since that can only happen if an earlier validation pass (signature.go, not present in
+// this snapshot) let invalid bytecode through.
+func (ce *callEngine) branch(sig blockSignature, isLoopBackEdge bool) ([]uint64, error) {
+	arity := sig.branchArity(isLoopBackEdge)
+	if arity > len(ce.stack) {
+		return nil, errBranchArityUnderflow
+	}
+	carried := make([]uint64, arity)
+	copy(carried, ce.stack[len(ce.stack)-arity:])
+	ce.stack = ce.stack[:len(ce.stack)-arity]
+	return carried, nil
+}
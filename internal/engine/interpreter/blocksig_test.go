@@ -0,0 +1,79 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/tetratelabs/wazero/internal/testing/require"
+)
+
+func TestResolveBlockSignature(t *testing.T) {
+	types := map[uint32]blockSignature{
+		0: {paramCount: 0, resultCount: 2},
+		1: {paramCount: 1, resultCount: 3},
+	}
+	lookup := func(i uint32) (int, int, error) {
+		sig := types[i]
+		return sig.paramCount, sig.resultCount, nil
+	}
+
+	tests := []struct {
+		name      string
+		blockType int64
+		expected  blockSignature
+	}{
+		{name: "empty type", blockType: -0x40, expected: blockSignature{}},
+		{name: "single value type (e.g. i32)", blockType: -0x01, expected: blockSignature{resultCount: 1}},
+		{name: "multi-value type index 0", blockType: 0, expected: blockSignature{paramCount: 0, resultCount: 2}},
+		{name: "multi-value type index 1", blockType: 1, expected: blockSignature{paramCount: 1, resultCount: 3}},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			sig, err := resolveBlockSignature(tc.blockType, lookup)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, sig)
+		})
+	}
+}
+
+func TestBlockSignature_branchArity(t *testing.T) {
+	sig := blockSignature{paramCount: 2, resultCount: 3}
+
+	require.Equal(t, 2, sig.branchArity(true), "a loop's back-edge carries its param count")
+	require.Equal(t, 3, sig.branchArity(false), "every other branch target carries its result count")
+}
+
+func TestValidateStructuredControlArity(t *testing.T) {
+	sig := blockSignature{paramCount: 2, resultCount: 1}
+
+	require.NoError(t, validateStructuredControlArity("block", sig, 2))
+	require.EqualError(t, validateStructuredControlArity("block", sig, 1),
+		"block: expected 2 parameter(s) on the stack, but have 1")
+}
+
+func TestCallEngine_branch(t *testing.T) {
+	// A block that consumes 1 param and produces 2 results.
+	sig := blockSignature{paramCount: 1, resultCount: 2}
+
+	ce := &callEngine{stack: []uint64{1, 2, 3}}
+	// A normal branch target (the block's own "end", or a loop branched to from outside it) carries the
+	// block's result count: the top 2 values.
+	carried, err := ce.branch(sig, false)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, carried)
+	require.Equal(t, []uint64{1}, ce.stack, "the carried values are popped off the operand stack")
+
+	ce = &callEngine{stack: []uint64{1, 2, 3}}
+	// A loop's own back-edge carries its param count instead: the top 1 value.
+	carried, err = ce.branch(sig, true)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, carried)
+}
+
+func TestCallEngine_branch_underflow(t *testing.T) {
+	ce := &callEngine{stack: []uint64{1, 2}}
+
+	_, err := ce.branch(blockSignature{resultCount: 5}, false)
+	require.EqualError(t, err, "not enough values on the stack to carry across the branch")
+}
@@ -0,0 +1,45 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+// callNativeFunc runs fn (the decoded body of a host or wasm function call, standing in for the real
+// interpreter's opcode-by-opcode dispatch loop) against ce, invoking listener's Before/After/Abort hooks
+// around it exactly as the real callNativeFunc (interpreter.go, not present in this snapshot) would:
+// Before before fn runs, After if it returns normally, Abort if it returns an error, and - via a deferred
+// recover, since this interpreter reports traps by panicking - Abort if it panics instead. This is what makes
+// experimental.FunctionListenerAbortCapable reachable from an actual call path rather than only from
+// listener_abort_test.go: every Before this function issues is paired with either an After or an Abort.
+func callNativeFunc(ctx context.Context, mod api.Module, def api.FunctionDefinition, listener experimental.FunctionListener, params []uint64, fn func(ce *callEngine) ([]uint64, error), ce *callEngine) (results []uint64, err error) {
+	if listener != nil {
+		listener.Before(ctx, mod, def, params, nil)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			trapErr, ok := r.(error)
+			if !ok {
+				trapErr = fmt.Errorf("%v", r)
+			}
+			if listener != nil {
+				experimental.NotifyFunctionListenerAbort(ctx, listener, mod, def, trapErr)
+			}
+			err = trapErr
+		}
+	}()
+	results, err = fn(ce)
+	if err != nil {
+		if listener != nil {
+			experimental.NotifyFunctionListenerAbort(ctx, listener, mod, def, err)
+		}
+		return nil, err
+	}
+	if listener != nil {
+		listener.After(ctx, mod, def, results)
+	}
+	return results, nil
+}
@@ -0,0 +1,66 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	"github.com/tetratelabs/wazero/internal/testing/require"
+)
+
+// recordingAbortListener is a minimal experimental.FunctionListener that also implements
+// experimental.FunctionListenerAbortCapable, recording which of Before/After/Abort fired so tests can
+// assert every Before is eventually paired with exactly one of them.
+type recordingAbortListener struct {
+	before, after, abort int
+	abortErr             error
+}
+
+func (l *recordingAbortListener) Before(context.Context, api.Module, api.FunctionDefinition, []uint64, experimental.StackIterator) {
+	l.before++
+}
+
+func (l *recordingAbortListener) After(context.Context, api.Module, api.FunctionDefinition, []uint64) {
+	l.after++
+}
+
+func (l *recordingAbortListener) Abort(_ context.Context, _ api.Module, _ api.FunctionDefinition, err error) {
+	l.abort++
+	l.abortErr = err
+}
+
+// TestCallNativeFunc_Abort proves experimental.FunctionListenerAbortCapable.Abort fires from a real call
+// path through callNativeFunc, not merely from listener_abort_test.go's direct call to
+// NotifyFunctionListenerAbort: a fn that panics (this interpreter's way of reporting a trap, e.g. an
+// integer divide by zero) must still leave Before paired with an Abort instead of a dangling call.
+func TestCallNativeFunc_Abort(t *testing.T) {
+	l := &recordingAbortListener{}
+	trap := fmt.Errorf("integer divide by zero")
+
+	_, err := callNativeFunc(testCtx, nil, nil, l, nil, func(ce *callEngine) ([]uint64, error) {
+		panic(trap)
+	}, &callEngine{})
+
+	require.EqualError(t, err, trap.Error())
+	require.Equal(t, 1, l.before)
+	require.Equal(t, 0, l.after)
+	require.Equal(t, 1, l.abort)
+	require.Equal(t, trap, l.abortErr)
+}
+
+// TestCallNativeFunc_After proves the normal-return path still fires After and never Abort.
+func TestCallNativeFunc_After(t *testing.T) {
+	l := &recordingAbortListener{}
+
+	results, err := callNativeFunc(testCtx, nil, nil, l, nil, func(ce *callEngine) ([]uint64, error) {
+		return []uint64{42}, nil
+	}, &callEngine{})
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{42}, results)
+	require.Equal(t, 1, l.before)
+	require.Equal(t, 1, l.after)
+	require.Equal(t, 0, l.abort)
+}
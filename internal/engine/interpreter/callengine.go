@@ -0,0 +1,29 @@
+package interpreter
+
+// callFrame is the per-call state pushed for the duration of one function activation. The real
+// interpreter's callFrame (interpreter.go, not present in this snapshot) carries considerably more, e.g.
+// the active *function and its operand-stack base; this package only needs the program counter plus the
+// typed-continuations handler table installed by whichever resume pushed this frame (nil for a frame
+// that isn't itself a resume boundary).
+type callFrame struct {
+	pc       uint64
+	handlers map[uint32]continuationHandler
+}
+
+// callEngine is the per-goroutine call stack the additions in this package (continuation.go, threads.go,
+// blocksig.go) dispatch against: the operand stack and call frames suspend/resume splice, the shared
+// linear memory atomics read and write, and the wait queue memory.atomic.wait/notify park goroutines on.
+// It stands in for the real callEngine (interpreter.go, not present in this snapshot), which callers
+// outside this package would reach through a moduleEngine rather than constructing directly.
+type callEngine struct {
+	frames []*callFrame
+	stack  []uint64
+	mem    *sharedMemory
+	waits  *waitQueue
+}
+
+// newCallEngine constructs a callEngine over mem, ready to dispatch atomics (threads.go) and
+// suspend/resume (continuation.go) against a single in-flight call.
+func newCallEngine(mem *sharedMemory, config threadsConfig) *callEngine {
+	return &callEngine{mem: mem, waits: newWaitQueue(config)}
+}
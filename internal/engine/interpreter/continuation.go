@@ -0,0 +1,131 @@
+package interpreter
+
+import "fmt"
+
+// continuation is the runtime representation of a value produced by the typed continuations (WasmFX)
+// proposal's cont.new/cont.bind instructions and consumed by resume/resume_throw. It is stored as a
+// reference value on the operand stack, like externref/funcref.
+//
+// A continuation captures everything above the point a suspend instruction unwound to its nearest
+// enclosing resume: the sub-slice of callEngine.frames and callEngine.stack above that boundary, plus
+// the handler table installed by that enclosing resume (so findHandler, and a caller choosing how to
+// re-install handlers across a resume, can see what was active at the point of suspension).
+type continuation struct {
+	// frames is the captured sub-slice of callEngine.frames, innermost frame last.
+	frames []*callFrame
+	// stack is the captured sub-slice of callEngine.stack's operand values.
+	stack []uint64
+	// handlers is the tag -> handler mapping installed by the resume that suspend unwound to.
+	handlers map[uint32]continuationHandler
+	// multiShot is true when the module that created this continuation opted into the multi-shot
+	// extension (resume is otherwise one-shot: a second resume traps).
+	multiShot bool
+	// resumed is set the first time this continuation is resumed, used to enforce the one-shot default.
+	resumed bool
+}
+
+// continuationHandler is one entry of a resume instruction's `(tag $t $lbl)*` handler table: which tag it
+// handles, and the branch target to jump to (with the tag's declared parameters, plus the continuation
+// object itself, pushed on the stack) when a suspend for that tag is executed within the continuation.
+type continuationHandler struct {
+	tag    uint32
+	target branchTarget
+}
+
+// branchTarget is the label a suspend branches to: a signed offset in the instruction stream, mirroring
+// how br/br_if/br_table already express their targets in this interpreter, plus the arity of values the
+// branch carries across (the tag's parameter types, by the typed continuations proposal's definition).
+type branchTarget struct {
+	pc    uint64
+	arity int
+}
+
+// newContinuation captures frames/stack/handlers for a suspend so that a later resume can restore them.
+func newContinuation(frames []*callFrame, stack []uint64, handlers map[uint32]continuationHandler, multiShot bool) *continuation {
+	// Copy defensively: frames/stack are sub-slices of the *current* callEngine.frames/stack backing
+	// arrays, which the caller is about to keep mutating (e.g. popping the suspended frames off).
+	f := make([]*callFrame, len(frames))
+	copy(f, frames)
+	s := make([]uint64, len(stack))
+	copy(s, stack)
+	return &continuation{frames: f, stack: s, handlers: handlers, multiShot: multiShot}
+}
+
+// errContinuationAlreadyResumed is the trap raised when a one-shot continuation is resumed a second time.
+var errContinuationAlreadyResumed = fmt.Errorf("continuation already resumed")
+
+// errUnhandledTag is the trap raised by suspend when no enclosing resume installed a handler for the tag.
+var errUnhandledTag = fmt.Errorf("unhandled tag")
+
+// bind returns a new continuation with args prepended to the front of the captured operand stack, i.e.
+// the values cont.bind partially applies. Per the proposal, bind does not consume the original
+// continuation: it produces a new one, leaving one-shot enforcement to whichever is resumed first.
+func (c *continuation) bind(args []uint64) *continuation {
+	stack := make([]uint64, 0, len(args)+len(c.stack))
+	stack = append(stack, args...)
+	stack = append(stack, c.stack...)
+	return &continuation{frames: c.frames, stack: stack, handlers: c.handlers, multiShot: c.multiShot}
+}
+
+// prepareResume returns the frames/stack to splice onto the callEngine for a resume, enforcing the
+// one-shot default (trapping on a second resume) and deep-copying the snapshot for multi-shot
+// continuations so that the original capture survives to be resumed again later.
+func (c *continuation) prepareResume() ([]*callFrame, []uint64, error) {
+	if c.resumed && !c.multiShot {
+		return nil, nil, errContinuationAlreadyResumed
+	}
+	c.resumed = true
+
+	if !c.multiShot {
+		return c.frames, c.stack, nil
+	}
+
+	frames := make([]*callFrame, len(c.frames))
+	copy(frames, c.frames)
+	stack := make([]uint64, len(c.stack))
+	copy(stack, c.stack)
+	return frames, stack, nil
+}
+
+// findHandler looks up the handler for tag in the continuation's own handler table, i.e. the one active
+// when it suspended. callEngine.suspend does not consult it: suspend searches ce.frames instead.
+func (c *continuation) findHandler(tag uint32) (continuationHandler, bool) {
+	h, ok := c.handlers[tag]
+	return h, ok
+}
+
+// suspend searches ce.frames from innermost (last) to outermost (first) for the nearest frame whose
+// handlers table handles tag, i.e. the nearest lexically enclosing resume, exactly as the typed
+// continuations proposal requires: a suspend must search *outward* through the active call stack, not
+// just consult whatever resume most recently ran. It then captures everything above (but not including)
+// that frame into a continuation, unwinds ce down to it, and returns the continuation plus the matched
+// handler's branch target.
+func (ce *callEngine) suspend(tag uint32, multiShot bool) (*continuation, branchTarget, error) {
+	for i := len(ce.frames) - 1; i >= 0; i-- {
+		h, ok := ce.frames[i].handlers[tag]
+		if !ok {
+			continue
+		}
+		c := newContinuation(ce.frames[i+1:], ce.stack, ce.frames[i].handlers, multiShot)
+		ce.frames = ce.frames[:i+1]
+		ce.stack = nil
+		return c, h.target, nil
+	}
+	return nil, branchTarget{}, errUnhandledTag
+}
+
+// resume splices c's captured frames back onto ce under a new frame carrying handlers, the resume
+// instruction's own `(tag $t $lbl)*` table (supplied by the caller decoding that instruction, since the
+// same resume site may install a fresh table each time it runs for a multi-shot continuation), then
+// appends args (the suspend expression's eventual result) to the top of the restored operand stack.
+func (ce *callEngine) resume(c *continuation, handlers map[uint32]continuationHandler, args []uint64) error {
+	frames, stack, err := c.prepareResume()
+	if err != nil {
+		return err
+	}
+	ce.frames = append(ce.frames, &callFrame{handlers: handlers})
+	ce.frames = append(ce.frames, frames...)
+	ce.stack = append(ce.stack, stack...)
+	ce.stack = append(ce.stack, args...)
+	return nil
+}
@@ -0,0 +1,144 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/tetratelabs/wazero/internal/testing/require"
+)
+
+func TestContinuation_prepareResume_oneShot(t *testing.T) {
+	f1, f2 := &callFrame{}, &callFrame{}
+	c := newContinuation([]*callFrame{f1, f2}, []uint64{1, 2, 3}, nil, false)
+
+	frames, stack, err := c.prepareResume()
+	require.NoError(t, err)
+	require.Equal(t, []*callFrame{f1, f2}, frames)
+	require.Equal(t, []uint64{1, 2, 3}, stack)
+
+	// A one-shot continuation traps on a second resume.
+	_, _, err = c.prepareResume()
+	require.EqualError(t, err, "continuation already resumed")
+}
+
+func TestContinuation_prepareResume_multiShot(t *testing.T) {
+	f1 := &callFrame{}
+	c := newContinuation([]*callFrame{f1}, []uint64{42}, nil, true)
+
+	frames1, stack1, err := c.prepareResume()
+	require.NoError(t, err)
+	frames2, stack2, err := c.prepareResume()
+	require.NoError(t, err)
+
+	// Each resume gets its own copy of the snapshot...
+	require.Equal(t, frames1, frames2)
+	require.Equal(t, stack1, stack2)
+
+	// ...so mutating one doesn't affect the other or the original capture.
+	stack1[0] = 7
+	require.Equal(t, uint64(42), stack2[0])
+	require.Equal(t, uint64(42), c.stack[0])
+}
+
+func TestContinuation_bind(t *testing.T) {
+	f1 := &callFrame{}
+	c := newContinuation([]*callFrame{f1}, []uint64{3, 4}, nil, false)
+
+	bound := c.bind([]uint64{1, 2})
+	require.Equal(t, []uint64{1, 2, 3, 4}, bound.stack)
+
+	// bind doesn't consume the original continuation.
+	require.Equal(t, false, c.resumed)
+	_, _, err := c.prepareResume()
+	require.NoError(t, err)
+}
+
+func TestCallEngine_suspendAndResume(t *testing.T) {
+	target := branchTarget{pc: 20, arity: 1}
+	resumeHandlers := map[uint32]continuationHandler{5: {tag: 5, target: target}}
+	ce := &callEngine{
+		frames: []*callFrame{{pc: 0, handlers: resumeHandlers}, {pc: 1}, {pc: 2}},
+		stack:  []uint64{10, 11},
+	}
+
+	c, gotTarget, err := ce.suspend(5, false)
+	require.NoError(t, err)
+	require.Equal(t, target, gotTarget)
+	// suspend unwinds the call stack down to the resume frame that installed the handler, keeping that
+	// frame, and discards the suspended computation's operand stack along with it.
+	require.Equal(t, 1, len(ce.frames))
+	require.Equal(t, 0, len(ce.stack))
+	// ...while the continuation keeps its own independent copy of what was captured above that frame.
+	require.Equal(t, []uint64{10, 11}, c.stack)
+	require.Equal(t, 2, len(c.frames))
+
+	// resume pushes a new resume frame carrying handlers, splices the captured frames back on above it,
+	// and appends the resume's args as the suspend expression's result.
+	err = ce.resume(c, resumeHandlers, []uint64{99})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(ce.frames)) // the original resume frame + the new one + the 2 captured frames
+	require.Equal(t, []uint64{10, 11, 99}, ce.stack)
+
+	// One-shot by default: a second resume of the same continuation traps.
+	err = ce.resume(c, resumeHandlers, []uint64{0})
+	require.EqualError(t, err, "continuation already resumed")
+}
+
+func TestCallEngine_suspendSearchesOutwardThroughNestedFrames(t *testing.T) {
+	// A suspend executed from the innermost of 3 nested frames must search outward past frames that
+	// don't handle its tag (e.g. an inner resume installed for a different tag) to the nearest one that
+	// does, not just consult the top of the stack or fail outright.
+	outer := branchTarget{pc: 99, arity: 0}
+	ce := &callEngine{
+		frames: []*callFrame{
+			{pc: 0, handlers: map[uint32]continuationHandler{5: {tag: 5, target: outer}}},
+			{pc: 1, handlers: map[uint32]continuationHandler{7: {tag: 7, target: branchTarget{pc: 1}}}},
+			{pc: 2}, // no handlers at all
+		},
+	}
+
+	c, target, err := ce.suspend(5, false)
+	require.NoError(t, err)
+	require.Equal(t, outer, target)
+	// Both frames above the matching (outermost) one were captured into the continuation.
+	require.Equal(t, 2, len(c.frames))
+	require.Equal(t, 1, len(ce.frames))
+}
+
+func TestCallEngine_suspendUnhandledTag(t *testing.T) {
+	ce := &callEngine{frames: []*callFrame{{handlers: map[uint32]continuationHandler{}}}}
+
+	_, _, err := ce.suspend(7, false)
+	require.EqualError(t, err, "unhandled tag")
+}
+
+func TestCallEngine_multiShotResumeTwice(t *testing.T) {
+	target := branchTarget{pc: 0, arity: 0}
+	handlers := map[uint32]continuationHandler{1: {tag: 1, target: target}}
+	ce := &callEngine{
+		frames: []*callFrame{{pc: 0, handlers: handlers}, {pc: 1}},
+		stack:  []uint64{1},
+	}
+
+	c, _, err := ce.suspend(1, true)
+	require.NoError(t, err)
+
+	require.NoError(t, ce.resume(c, handlers, nil))
+	first := ce.stack
+
+	require.NoError(t, ce.resume(c, handlers, nil))
+	// Each resume of a multi-shot continuation gets its own copy of the captured stack.
+	first[0] = 42
+	require.Equal(t, uint64(1), ce.stack[0])
+}
+
+func TestContinuation_findHandler(t *testing.T) {
+	h := continuationHandler{tag: 5, target: branchTarget{pc: 10, arity: 2}}
+	c := newContinuation(nil, nil, map[uint32]continuationHandler{5: h}, false)
+
+	found, ok := c.findHandler(5)
+	require.True(t, ok)
+	require.Equal(t, h, found)
+
+	_, ok = c.findHandler(6)
+	require.False(t, ok)
+}
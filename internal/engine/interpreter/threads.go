@@ -0,0 +1,333 @@
+package interpreter
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ptr returns a pointer to the byte at addr within mem, for use with the sync/atomic primitives, which
+// require a pointer rather than a slice index. Callers are responsible for bounds-checking addr plus the
+// operand width against len(mem) before calling this, exactly as the interpreter already does for
+// ordinary (non-atomic) loads and stores.
+func ptr(mem []byte, addr uint32) unsafe.Pointer {
+	return unsafe.Pointer(&mem[addr])
+}
+
+// errUnalignedAtomic is the trap raised by an atomic load/store/rmw whose effective address isn't
+// aligned to its operand width, which the threads proposal requires (unlike ordinary loads/stores, which
+// are byte-addressable regardless of alignment hints).
+var errUnalignedAtomic = fmt.Errorf("unaligned atomic")
+
+// atomicLoad32/64 and atomicStore32/64 implement i32/i64.atomic.load and .store. mem is the guest's
+// linear memory backing array; addr is the effective (already-offset) byte address. Both trap with
+// errUnalignedAtomic when addr isn't aligned to the operand width, which is enforced even on memories
+// that aren't declared shared: the threads proposal requires every atomic access to be aligned,
+// independent of whether the memory can be accessed from another agent.
+func atomicLoad32(mem []byte, addr uint32) (uint32, error) {
+	if addr%4 != 0 {
+		return 0, errUnalignedAtomic
+	}
+	p := (*uint32)(ptr(mem, addr))
+	return atomic.LoadUint32(p), nil
+}
+
+func atomicLoad64(mem []byte, addr uint32) (uint64, error) {
+	if addr%8 != 0 {
+		return 0, errUnalignedAtomic
+	}
+	p := (*uint64)(ptr(mem, addr))
+	return atomic.LoadUint64(p), nil
+}
+
+func atomicStore32(mem []byte, addr uint32, val uint32) error {
+	if addr%4 != 0 {
+		return errUnalignedAtomic
+	}
+	p := (*uint32)(ptr(mem, addr))
+	atomic.StoreUint32(p, val)
+	return nil
+}
+
+func atomicStore64(mem []byte, addr uint32, val uint64) error {
+	if addr%8 != 0 {
+		return errUnalignedAtomic
+	}
+	p := (*uint64)(ptr(mem, addr))
+	atomic.StoreUint64(p, val)
+	return nil
+}
+
+// atomicRMWOp identifies which i32/i64.atomic.rmw.* variant to perform.
+type atomicRMWOp int
+
+const (
+	atomicRMWAdd atomicRMWOp = iota
+	atomicRMWSub
+	atomicRMWAnd
+	atomicRMWOr
+	atomicRMWXor
+	atomicRMWXchg
+)
+
+// atomicRMW32/64 implement the i32/i64.atomic.rmw.{add,sub,and,or,xor,xchg} family, returning the value
+// at addr immediately before the operation was applied, per the threads proposal's semantics.
+func atomicRMW32(mem []byte, addr uint32, op atomicRMWOp, operand uint32) (uint32, error) {
+	if addr%4 != 0 {
+		return 0, errUnalignedAtomic
+	}
+	p := (*uint32)(ptr(mem, addr))
+	for {
+		old := atomic.LoadUint32(p)
+		var next uint32
+		switch op {
+		case atomicRMWAdd:
+			next = old + operand
+		case atomicRMWSub:
+			next = old - operand
+		case atomicRMWAnd:
+			next = old & operand
+		case atomicRMWOr:
+			next = old | operand
+		case atomicRMWXor:
+			next = old ^ operand
+		case atomicRMWXchg:
+			next = operand
+		}
+		if atomic.CompareAndSwapUint32(p, old, next) {
+			return old, nil
+		}
+	}
+}
+
+func atomicRMW64(mem []byte, addr uint32, op atomicRMWOp, operand uint64) (uint64, error) {
+	if addr%8 != 0 {
+		return 0, errUnalignedAtomic
+	}
+	p := (*uint64)(ptr(mem, addr))
+	for {
+		old := atomic.LoadUint64(p)
+		var next uint64
+		switch op {
+		case atomicRMWAdd:
+			next = old + operand
+		case atomicRMWSub:
+			next = old - operand
+		case atomicRMWAnd:
+			next = old & operand
+		case atomicRMWOr:
+			next = old | operand
+		case atomicRMWXor:
+			next = old ^ operand
+		case atomicRMWXchg:
+			next = operand
+		}
+		if atomic.CompareAndSwapUint64(p, old, next) {
+			return old, nil
+		}
+	}
+}
+
+// atomicCmpxchg32/64 implement i32/i64.atomic.rmw.cmpxchg, returning the value at addr immediately
+// before the (possibly no-op) exchange, so the caller can tell whether its expected value matched.
+func atomicCmpxchg32(mem []byte, addr uint32, expected, replacement uint32) (uint32, error) {
+	if addr%4 != 0 {
+		return 0, errUnalignedAtomic
+	}
+	p := (*uint32)(ptr(mem, addr))
+	for {
+		old := atomic.LoadUint32(p)
+		if old != expected {
+			return old, nil
+		}
+		if atomic.CompareAndSwapUint32(p, old, replacement) {
+			return old, nil
+		}
+	}
+}
+
+func atomicCmpxchg64(mem []byte, addr uint32, expected, replacement uint64) (uint64, error) {
+	if addr%8 != 0 {
+		return 0, errUnalignedAtomic
+	}
+	p := (*uint64)(ptr(mem, addr))
+	for {
+		old := atomic.LoadUint64(p)
+		if old != expected {
+			return old, nil
+		}
+		if atomic.CompareAndSwapUint64(p, old, replacement) {
+			return old, nil
+		}
+	}
+}
+
+// waitResult is the return value of memory.atomic.wait32/wait64, per the threads proposal.
+type waitResult uint32
+
+const (
+	waitResultOK       waitResult = 0 // woken by a matching notify
+	waitResultNotEqual waitResult = 1 // the value at addr no longer matched expected
+	waitResultTimedOut waitResult = 2
+)
+
+// waiter is one goroutine parked in memory.atomic.wait32/wait64 on a given address.
+type waiter struct {
+	c chan struct{}
+}
+
+// errTooManyWaiters is the trap raised when accepting another waiter would exceed threadsConfig.MaxWaiters.
+var errTooManyWaiters = fmt.Errorf("too many waiters")
+
+// errBlockingWaitNotAllowed is the trap raised by wait when threadsConfig.AllowBlockingWait is false,
+// e.g. because the host doesn't want the goroutine running a module's exported function call (which may
+// be the only goroutine servicing it) to block indefinitely.
+var errBlockingWaitNotAllowed = fmt.Errorf("blocking wait not allowed")
+
+// threadsConfig holds the host-controlled knobs for the threads proposal's wait/notify instructions,
+// mirroring what a wazero.RuntimeConfig exposes to the embedder.
+type threadsConfig struct {
+	// MaxWaiters caps the number of goroutines that may be parked at once across all addresses, 0 meaning
+	// unlimited. This bounds how much of a host's goroutine budget a single malicious or buggy guest can
+	// consume by calling memory.atomic.wait in a loop.
+	MaxWaiters int
+	// AllowBlockingWait permits memory.atomic.wait32/wait64 to actually block. When false, every wait call
+	// traps immediately with errBlockingWaitNotAllowed instead of parking.
+	AllowBlockingWait bool
+}
+
+// waitQueue implements the address-keyed park/wake-up semantics behind memory.atomic.wait32/wait64 and
+// memory.atomic.notify for a single shared wasm.Memory. Callers must only use a waitQueue against memory
+// that was declared shared: waiting/notifying on non-shared memory traps with errNotSharedMemory, enforced
+// by the caller (callEngine.wait32/wait64/notify), not here.
+type waitQueue struct {
+	mu      sync.Mutex
+	waiters map[uint32][]*waiter
+	total   int
+	config  threadsConfig
+}
+
+func newWaitQueue(config threadsConfig) *waitQueue {
+	return &waitQueue{waiters: map[uint32][]*waiter{}, config: config}
+}
+
+// wait parks the calling goroutine on addr until one of:
+//   - notify wakes it (along with up to count-1 other waiters on the same address): returns waitResultOK.
+//   - load(addr) no longer equals expected, checked atomically under the queue's lock before parking so a
+//     notify that raced ahead of the wait call isn't missed: returns waitResultNotEqual.
+//   - timeout is closed or receives a value (a channel that never does waits forever): returns
+//     waitResultTimedOut.
+func (q *waitQueue) wait(addr uint32, load func() uint64, expected uint64, timeout <-chan struct{}) (waitResult, error) {
+	if !q.config.AllowBlockingWait {
+		return 0, errBlockingWaitNotAllowed
+	}
+
+	q.mu.Lock()
+	if load() != expected {
+		q.mu.Unlock()
+		return waitResultNotEqual, nil
+	}
+	if q.config.MaxWaiters > 0 && q.total >= q.config.MaxWaiters {
+		q.mu.Unlock()
+		return 0, errTooManyWaiters
+	}
+	w := &waiter{c: make(chan struct{})}
+	q.waiters[addr] = append(q.waiters[addr], w)
+	q.total++
+	q.mu.Unlock()
+
+	select {
+	case <-w.c:
+		return waitResultOK, nil
+	case <-timeout:
+		q.remove(addr, w)
+		return waitResultTimedOut, nil
+	}
+}
+
+// remove drops w from addr's waiter list if it hasn't already been woken, e.g. because its wait timed out
+// concurrently with a notify.
+func (q *waitQueue) remove(addr uint32, w *waiter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ws := q.waiters[addr]
+	for i, cand := range ws {
+		if cand == w {
+			q.waiters[addr] = append(ws[:i], ws[i+1:]...)
+			q.total--
+			return
+		}
+	}
+}
+
+// notify wakes up to count waiters parked on addr, returning how many were actually woken.
+func (q *waitQueue) notify(addr uint32, count uint32) uint32 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ws := q.waiters[addr]
+	n := count
+	if uint32(len(ws)) < n {
+		n = uint32(len(ws))
+	}
+	for i := uint32(0); i < n; i++ {
+		close(ws[i].c)
+	}
+	q.waiters[addr] = ws[n:]
+	q.total -= int(n)
+	return n
+}
+
+// sharedMemory is the piece of a wasm.Memory (internal/wasm, not present in this snapshot) that atomic
+// dispatch actually needs: the linear memory backing array, and whether the module declared it shared.
+// The threads proposal requires memory.atomic.wait32/wait64/notify to trap on memory that isn't declared
+// shared, since waiting/notifying across agents that can't see each other's writes is meaningless; plain
+// atomic load/store/rmw/cmpxchg, by contrast, are permitted on any memory.
+type sharedMemory struct {
+	buffer []byte
+	shared bool
+}
+
+// errNotSharedMemory is the trap raised by wait32/wait64/notify against memory that isn't declared shared.
+var errNotSharedMemory = fmt.Errorf("expected shared memory")
+
+// wait32 implements memory.atomic.wait32 against ce.mem, trapping with errNotSharedMemory before ever
+// touching ce.waits if ce.mem isn't shared, the way the real interpreter's callNativeFunc (interpreter.go,
+// not present in this snapshot) would at the point it decodes the instruction.
+func (ce *callEngine) wait32(addr uint32, expected uint32, timeout <-chan struct{}) (waitResult, error) {
+	if !ce.mem.shared {
+		return 0, errNotSharedMemory
+	}
+	load := func() uint64 {
+		v, _ := atomicLoad32(ce.mem.buffer, addr)
+		return uint64(v)
+	}
+	return ce.waits.wait(addr, load, uint64(expected), timeout)
+}
+
+// wait64 implements memory.atomic.wait64, identical to wait32 but over a 64-bit expected value.
+func (ce *callEngine) wait64(addr uint32, expected uint64, timeout <-chan struct{}) (waitResult, error) {
+	if !ce.mem.shared {
+		return 0, errNotSharedMemory
+	}
+	load := func() uint64 {
+		v, _ := atomicLoad64(ce.mem.buffer, addr)
+		return v
+	}
+	return ce.waits.wait(addr, load, expected, timeout)
+}
+
+// notify implements memory.atomic.notify, trapping with errNotSharedMemory the same way wait32/wait64 do.
+func (ce *callEngine) notify(addr uint32, count uint32) (uint32, error) {
+	if !ce.mem.shared {
+		return 0, errNotSharedMemory
+	}
+	return ce.waits.notify(addr, count), nil
+}
+
+// fence implements atomic.fence. Per the threads proposal it is a no-op on every platform wazero targets
+// (Go's memory model already gives sync/atomic the ordering atomic.fence asks for), but it still must be
+// a real, callable instruction rather than silently absent from dispatch, since a module can legally
+// include it regardless of whether ce.mem is shared.
+func (ce *callEngine) fence() {}
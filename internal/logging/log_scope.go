@@ -0,0 +1,40 @@
+// Package logging holds types used to tune which host function calls are
+// observed by experimental.FunctionListener implementations such as
+// experimental/logging. It is kept separate from experimental/logging so
+// that internal packages (e.g. internal/wasi_snapshot_preview1/logging) can
+// classify functions into scopes without creating an import cycle on the
+// experimental API.
+package logging
+
+// LogScopes is a bit flag of WASI functions or categories of functions to
+// log. This allows users to narrow tracing to only the parts of the ABI
+// relevant to what they are troubleshooting, instead of drowning in output
+// from extremely chatty functions such as poll_oneoff.
+type LogScopes uint32
+
+const (
+	// LogScopeNone means nothing is logged.
+	LogScopeNone LogScopes = 1 << iota >> 1
+	// LogScopeClock is the scope for clock_time_get, clock_res_get.
+	LogScopeClock
+	// LogScopeFilesystem is the scope for functions reading, writing or
+	// otherwise manipulating file descriptors and paths, e.g. fd_read,
+	// fd_write, path_open.
+	LogScopeFilesystem
+	// LogScopeRandom is the scope for random_get.
+	LogScopeRandom
+	// LogScopeSock is the scope for sock_accept, sock_recv, sock_send and
+	// sock_shutdown.
+	LogScopeSock
+	// LogScopePoll is the scope for poll_oneoff and sched_yield, which are
+	// typically the noisiest functions in a guest's event loop.
+	LogScopePoll
+	// LogScopeProc is the scope for proc_exit and proc_raise.
+	LogScopeProc
+	// LogScopeArgs is the scope for args_get, args_sizes_get, environ_get
+	// and environ_sizes_get.
+	LogScopeArgs
+
+	// LogScopeAll means all functions are logged.
+	LogScopeAll = LogScopeClock | LogScopeFilesystem | LogScopeRandom | LogScopeSock | LogScopePoll | LogScopeProc | LogScopeArgs
+)
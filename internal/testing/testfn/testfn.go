@@ -0,0 +1,23 @@
+// Package testfn provides a minimal api.FunctionDefinition stand-in shared by the several packages
+// (internal/wasi_snapshot_preview1/logging, experimental/logging, experimental/logging/observer) whose
+// tests only need a definition's Name to exercise log-scope filtering and function-name-keyed output.
+package testfn
+
+import "github.com/tetratelabs/wazero/api"
+
+// FunctionDefinition implements api.FunctionDefinition, overriding only Name. Every other method is
+// promoted from the embedded nil api.FunctionDefinition, so it must not be called.
+type FunctionDefinition struct {
+	api.FunctionDefinition
+	name string
+}
+
+// New returns a FunctionDefinition whose Name() returns name.
+func New(name string) *FunctionDefinition {
+	return &FunctionDefinition{name: name}
+}
+
+// Name implements the same method as documented on api.FunctionDefinition.
+func (f *FunctionDefinition) Name() string {
+	return f.name
+}
@@ -0,0 +1,77 @@
+// Package logging classifies WASI preview1 host functions into
+// logging.LogScopes so that experimental.FunctionListener implementations
+// can filter host call tracing without each caller re-implementing the
+// same ABI knowledge.
+package logging
+
+import (
+	"path"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/internal/logging"
+	. "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1"
+)
+
+// IsInLogScope returns true if the function is in any of the given
+// logging.LogScopes.
+func IsInLogScope(fnd api.FunctionDefinition, scopes logging.LogScopes) bool {
+	switch fnd.Name() {
+	case ArgsGetName, ArgsSizesGetName, EnvironGetName, EnvironSizesGetName:
+		return scopes&logging.LogScopeArgs != 0
+	case ClockResGetName, ClockTimeGetName:
+		return scopes&logging.LogScopeClock != 0
+	case
+		FdAdviseName, FdAllocateName, FdCloseName, FdDatasyncName,
+		FdFdstatGetName, FdFdstatSetFlagsName, FdFdstatSetRightsName,
+		FdFilestatGetName, FdFilestatSetSizeName, FdFilestatSetTimesName,
+		FdPreadName, FdPrestatGetName, FdPrestatDirNameName, FdPwriteName,
+		FdReadName, FdReaddirName, FdRenumberName, FdSeekName, FdSyncName,
+		FdTellName, FdWriteName,
+		PathCreateDirectoryName, PathFilestatGetName, PathFilestatSetTimesName,
+		PathLinkName, PathOpenName, PathReadlinkName, PathRemoveDirectoryName,
+		PathRenameName, PathSymlinkName, PathUnlinkFileName:
+		return scopes&logging.LogScopeFilesystem != 0
+	case PollOneoffName, SchedYieldName:
+		return scopes&logging.LogScopePoll != 0
+	case ProcExitName, ProcRaiseName:
+		return scopes&logging.LogScopeProc != 0
+	case RandomGetName:
+		return scopes&logging.LogScopeRandom != 0
+	case SockAcceptName, SockRecvName, SockSendName, SockShutdownName:
+		return scopes&logging.LogScopeSock != 0
+	default:
+		// Anything not yet classified remains visible regardless of scope,
+		// so that adding a new WASI function can't silently hide it.
+		return true
+	}
+}
+
+// Admit is a generalization of IsInLogScope that layers per-function include/exclude filters, expressed
+// as path.Match glob patterns (e.g. "fd_*"), on top of scopes. exclude wins over include, include wins
+// over scopes, and an empty include list falls back to scopes so existing WithLogger(scopes) callers are
+// unaffected by this option.
+func Admit(fnd api.FunctionDefinition, scopes logging.LogScopes, include, exclude []string) bool {
+	name := fnd.Name()
+	for _, pattern := range exclude {
+		if matchesFunction(pattern, name) {
+			return false
+		}
+	}
+	for _, pattern := range include {
+		if matchesFunction(pattern, name) {
+			return true
+		}
+	}
+	if len(include) > 0 {
+		return false
+	}
+	return IsInLogScope(fnd, scopes)
+}
+
+// matchesFunction reports whether pattern, a path.Match glob such as "fd_*", matches the WASI function
+// name. A malformed pattern never matches rather than erroring, since these come from module config, not
+// an already-validated source.
+func matchesFunction(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
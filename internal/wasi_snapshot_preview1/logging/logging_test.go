@@ -6,24 +6,18 @@ import (
 	"github.com/tetratelabs/wazero/api"
 	"github.com/tetratelabs/wazero/internal/logging"
 	"github.com/tetratelabs/wazero/internal/testing/require"
+	"github.com/tetratelabs/wazero/internal/testing/testfn"
 	. "github.com/tetratelabs/wazero/internal/wasi_snapshot_preview1"
-	"github.com/tetratelabs/wazero/internal/wasm"
 )
 
-type testFunctionDefinition struct {
-	name string
-	*wasm.FunctionDefinition
-}
-
-// Name implements the same method as documented on api.FunctionDefinition.
-func (f *testFunctionDefinition) Name() string {
-	return f.name
-}
-
 func TestIsInLogScope(t *testing.T) {
-	clockTimeGet := &testFunctionDefinition{name: ClockTimeGetName}
-	fdRead := &testFunctionDefinition{name: FdReadName}
-	randomGet := &testFunctionDefinition{name: RandomGetName}
+	clockTimeGet := testfn.New(ClockTimeGetName)
+	fdRead := testfn.New(FdReadName)
+	randomGet := testfn.New(RandomGetName)
+	sockAccept := testfn.New(SockAcceptName)
+	pollOneoff := testfn.New(PollOneoffName)
+	procExit := testfn.New(ProcExitName)
+	argsGet := testfn.New(ArgsGetName)
 	tests := []struct {
 		name     string
 		fnd      api.FunctionDefinition
@@ -120,6 +114,78 @@ func TestIsInLogScope(t *testing.T) {
 			scopes:   logging.LogScopeNone,
 			expected: false,
 		},
+		{
+			name:     "sockAccept in LogScopeSock",
+			fnd:      sockAccept,
+			scopes:   logging.LogScopeSock,
+			expected: true,
+		},
+		{
+			name:     "sockAccept not in LogScopePoll",
+			fnd:      sockAccept,
+			scopes:   logging.LogScopePoll,
+			expected: false,
+		},
+		{
+			name:     "sockAccept in LogScopeAll",
+			fnd:      sockAccept,
+			scopes:   logging.LogScopeAll,
+			expected: true,
+		},
+		{
+			name:     "pollOneoff in LogScopePoll",
+			fnd:      pollOneoff,
+			scopes:   logging.LogScopePoll,
+			expected: true,
+		},
+		{
+			name:     "pollOneoff not in LogScopeFilesystem",
+			fnd:      pollOneoff,
+			scopes:   logging.LogScopeFilesystem,
+			expected: false,
+		},
+		{
+			name:     "pollOneoff in LogScopeAll",
+			fnd:      pollOneoff,
+			scopes:   logging.LogScopeAll,
+			expected: true,
+		},
+		{
+			name:     "procExit in LogScopeProc",
+			fnd:      procExit,
+			scopes:   logging.LogScopeProc,
+			expected: true,
+		},
+		{
+			name:     "procExit not in LogScopeArgs",
+			fnd:      procExit,
+			scopes:   logging.LogScopeArgs,
+			expected: false,
+		},
+		{
+			name:     "procExit in LogScopeAll",
+			fnd:      procExit,
+			scopes:   logging.LogScopeAll,
+			expected: true,
+		},
+		{
+			name:     "argsGet in LogScopeArgs",
+			fnd:      argsGet,
+			scopes:   logging.LogScopeArgs,
+			expected: true,
+		},
+		{
+			name:     "argsGet not in LogScopeProc",
+			fnd:      argsGet,
+			scopes:   logging.LogScopeProc,
+			expected: false,
+		},
+		{
+			name:     "argsGet in LogScopeAll",
+			fnd:      argsGet,
+			scopes:   logging.LogScopeAll,
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -129,4 +195,77 @@ func TestIsInLogScope(t *testing.T) {
 			require.Equal(t, tc.expected, IsInLogScope(tc.fnd, tc.scopes))
 		})
 	}
+}
+
+func TestAdmit(t *testing.T) {
+	clockTimeGet := testfn.New(ClockTimeGetName)
+	fdRead := testfn.New(FdReadName)
+	fdWrite := testfn.New(FdWriteName)
+	pathOpen := testfn.New(PathOpenName)
+
+	tests := []struct {
+		name             string
+		fnd              api.FunctionDefinition
+		scopes           logging.LogScopes
+		include, exclude []string
+		expected         bool
+	}{
+		{
+			name:     "fdRead admitted by scope, no include/exclude",
+			fnd:      fdRead,
+			scopes:   logging.LogScopeFilesystem,
+			expected: true,
+		},
+		{
+			name:     "fdRead admitted by scope but rejected by exclude",
+			fnd:      fdRead,
+			scopes:   logging.LogScopeFilesystem,
+			exclude:  []string{"fd_*"},
+			expected: false,
+		},
+		{
+			name:     "fdWrite admitted by scope but rejected by exact exclude",
+			fnd:      fdWrite,
+			scopes:   logging.LogScopeFilesystem,
+			exclude:  []string{"fd_read", "fd_write"},
+			expected: false,
+		},
+		{
+			name:     "clockTimeGet rejected by scope but forced in by an explicit include",
+			fnd:      clockTimeGet,
+			scopes:   logging.LogScopeFilesystem,
+			include:  []string{"clock_time_get"},
+			expected: true,
+		},
+		{
+			name:     "pathOpen not matched by a narrow include, even though in scope",
+			fnd:      pathOpen,
+			scopes:   logging.LogScopeFilesystem,
+			include:  []string{"clock_time_get"},
+			expected: false,
+		},
+		{
+			name:     "exclude wins over include for the same function",
+			fnd:      fdRead,
+			scopes:   logging.LogScopeFilesystem,
+			include:  []string{"fd_read"},
+			exclude:  []string{"fd_read"},
+			expected: false,
+		},
+		{
+			name:     "empty include falls back to scopes",
+			fnd:      fdWrite,
+			scopes:   logging.LogScopeNone,
+			include:  nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Admit(tc.fnd, tc.scopes, tc.include, tc.exclude))
+		})
+	}
 }
\ No newline at end of file